@@ -16,12 +16,44 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Option customizes how Do or DoTrace makes a request, e.g. WithSigner
+type Option func(*options)
+
+type options struct {
+	signer *Signer
+}
+
+// WithSigner returns an option which signs the request with the given Signer before sending,
+// adding Digest and Signature headers
+func WithSigner(signer *Signer) Option {
+	return func(o *options) { o.signer = signer }
+}
+
 // Do makes the given HTTP request using the current requestor and retry config
-func Do(client *http.Client, request *http.Request, retries *RetryConfig, access *AccessConfig) (*http.Response, error) {
+func Do(client *http.Client, request *http.Request, retries *RetryConfig, access *AccessConfig, opts ...Option) (*http.Response, error) {
+	if err := applyOptions(request, opts); err != nil {
+		return nil, err
+	}
+
 	r, _, err := do(client, request, retries, access)
 	return r, err
 }
 
+// applies the given options to the request, e.g. signing it if WithSigner was passed
+func applyOptions(request *http.Request, opts []Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.signer != nil {
+		if err := o.signer.Sign(request); err != nil {
+			return errors.Wrap(err, "error signing request")
+		}
+	}
+	return nil
+}
+
 func do(client *http.Client, request *http.Request, retries *RetryConfig, access *AccessConfig) (*http.Response, int, error) {
 	if access != nil {
 		allowed, err := access.Allow(request)
@@ -41,7 +73,7 @@ func do(client *http.Client, request *http.Request, retries *RetryConfig, access
 		response, err = currentRequestor.Do(client, request)
 
 		if retries != nil && retry < retries.MaxRetries() {
-			backoff := retries.Backoff(retry)
+			backoff := retries.Backoff(retry, response)
 
 			if retries.ShouldRetry(request, response, backoff) {
 				time.Sleep(backoff)
@@ -122,7 +154,11 @@ func replaceNullChars(b []byte) []byte {
 }
 
 // DoTrace makes the given request saving traces of the complete request and response
-func DoTrace(client *http.Client, request *http.Request, retries *RetryConfig, access *AccessConfig, maxBodyBytes int) (*Trace, error) {
+func DoTrace(client *http.Client, request *http.Request, retries *RetryConfig, access *AccessConfig, maxBodyBytes int, opts ...Option) (*Trace, error) {
+	if err := applyOptions(request, opts); err != nil {
+		return nil, err
+	}
+
 	requestTrace, err := httputil.DumpRequestOut(request, true)
 	if err != nil {
 		return nil, err
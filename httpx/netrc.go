@@ -0,0 +1,132 @@
+package httpx
+
+import (
+	"bufio"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// netrcEntry is the username/password pair for a single machine entry in a netrc file
+type netrcEntry struct {
+	login    string
+	password string
+}
+
+var netrcOnce sync.Once
+var netrcEntries map[string]*netrcEntry
+
+// NetrcAuth injects Basic auth credentials for the request's host from the user's netrc file
+// ($NETRC, or $HOME/.netrc), if it has no Authorization header already and a matching machine
+// entry, or failing that a default entry, is found. The netrc file is read and parsed once and cached for the life of the process.
+// This is opt-in - callers must invoke it explicitly before making the request, e.g.
+//
+//	httpx.NetrcAuth(request)
+//	response, err := httpx.Do(http.DefaultClient, request, nil, access)
+func NetrcAuth(request *http.Request) error {
+	if request.Header.Get("Authorization") != "" {
+		return nil
+	}
+
+	entries, err := loadNetrc()
+	if err != nil {
+		return err
+	}
+
+	entry := entries[request.URL.Hostname()]
+	if entry == nil {
+		entry = entries["default"]
+	}
+	if entry == nil {
+		return nil
+	}
+
+	request.Header.Set("Authorization", "Basic "+BasicAuth(entry.login, entry.password))
+	return nil
+}
+
+func loadNetrc() (map[string]*netrcEntry, error) {
+	var err error
+	netrcOnce.Do(func() {
+		netrcEntries, err = parseNetrcFile(netrcPath())
+	})
+	return netrcEntries, err
+}
+
+func netrcPath() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return home + "/.netrc"
+}
+
+// parses a netrc file into a map of machine (or "default") to entry. Missing files are treated
+// as having no entries rather than as an error, since netrc auth is always optional.
+func parseNetrcFile(path string) (map[string]*netrcEntry, error) {
+	entries := map[string]*netrcEntry{}
+	if path == "" {
+		return entries, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, errors.Wrap(err, "error opening netrc file")
+	}
+	defer file.Close()
+
+	fields := strings.Fields(readAll(file))
+
+	var machine string
+	var entry *netrcEntry
+
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine", "default":
+			if machine != "" && entry != nil {
+				entries[machine] = entry
+			}
+			entry = &netrcEntry{}
+			if fields[i] == "default" {
+				machine = "default"
+			} else if i+1 < len(fields) {
+				machine = fields[i+1]
+				i++
+			}
+		case "login":
+			if entry != nil && i+1 < len(fields) {
+				entry.login = fields[i+1]
+				i++
+			}
+		case "password":
+			if entry != nil && i+1 < len(fields) {
+				entry.password = fields[i+1]
+				i++
+			}
+		}
+	}
+	if machine != "" && entry != nil {
+		entries[machine] = entry
+	}
+
+	return entries, nil
+}
+
+func readAll(f *os.File) string {
+	b := &strings.Builder{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		b.WriteString(scanner.Text())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,34 @@
+package httpx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetrcFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".netrc")
+	require.NoError(t, os.WriteFile(path, []byte(`
+machine api.example.com
+login alice
+password s3cr3t
+
+default
+login bob
+password changeit
+`), 0600))
+
+	entries, err := parseNetrcFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, &netrcEntry{login: "alice", password: "s3cr3t"}, entries["api.example.com"])
+	assert.Equal(t, &netrcEntry{login: "bob", password: "changeit"}, entries["default"])
+
+	// a missing file is not an error, it just means no entries
+	entries, err = parseNetrcFile(filepath.Join(dir, "nonexistent"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,48 @@
+package httpx_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyaruka/gocommon/httpx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetrcAuth(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".netrc")
+	require.NoError(t, os.WriteFile(path, []byte(`
+machine api.example.com
+login alice
+password s3cr3t
+
+default
+login bob
+password changeit
+`), 0600))
+
+	t.Setenv("NETRC", path)
+
+	// matches a specific machine entry
+	request, err := http.NewRequest("GET", "https://api.example.com/webhook", nil)
+	require.NoError(t, err)
+	require.NoError(t, httpx.NetrcAuth(request))
+	assert.Equal(t, "Basic "+httpx.BasicAuth("alice", "s3cr3t"), request.Header.Get("Authorization"))
+
+	// falls back to the default entry for an unknown host
+	request, err = http.NewRequest("GET", "https://other.example.com/webhook", nil)
+	require.NoError(t, err)
+	require.NoError(t, httpx.NetrcAuth(request))
+	assert.Equal(t, "Basic "+httpx.BasicAuth("bob", "changeit"), request.Header.Get("Authorization"))
+
+	// leaves an existing Authorization header alone
+	request, err = http.NewRequest("GET", "https://api.example.com/webhook", nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer mytoken")
+	require.NoError(t, httpx.NetrcAuth(request))
+	assert.Equal(t, "Bearer mytoken", request.Header.Get("Authorization"))
+}
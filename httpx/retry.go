@@ -0,0 +1,239 @@
+package httpx
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryStrategy computes how long to wait before the next retry attempt. Implementations should
+// be safe for concurrent use since a single instance is typically shared across requests.
+type RetryStrategy interface {
+	// Backoff returns how long to wait before the given retry attempt (0-based), taking into
+	// account the response from the previous attempt if there was one
+	Backoff(retry int, response *http.Response) time.Duration
+}
+
+// ConstantStrategy waits the same amount of time before every retry
+type ConstantStrategy struct {
+	Wait time.Duration
+}
+
+// NewConstantStrategy creates a new constant backoff strategy
+func NewConstantStrategy(wait time.Duration) *ConstantStrategy {
+	return &ConstantStrategy{Wait: wait}
+}
+
+// Backoff waits a constant amount of time, honoring Retry-After if the response has one
+func (s *ConstantStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		return wait
+	}
+	return s.Wait
+}
+
+// LinearStrategy increases the wait time linearly with each retry, i.e. base, base*2, base*3, …
+type LinearStrategy struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NewLinearStrategy creates a new linear backoff strategy
+func NewLinearStrategy(base, cap time.Duration) *LinearStrategy {
+	return &LinearStrategy{Base: base, Cap: cap}
+}
+
+// Backoff waits base*(retry+1), capped at Cap, honoring Retry-After if the response has one
+func (s *LinearStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		return capped(wait, s.Cap)
+	}
+	return capped(s.Base*time.Duration(retry+1), s.Cap)
+}
+
+// ExponentialStrategy doubles the wait time with each retry, i.e. base, base*2, base*4, …
+type ExponentialStrategy struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NewExponentialStrategy creates a new exponential backoff strategy
+func NewExponentialStrategy(base, cap time.Duration) *ExponentialStrategy {
+	return &ExponentialStrategy{Base: base, Cap: cap}
+}
+
+// Backoff waits base*2^retry, capped at Cap, honoring Retry-After if the response has one
+func (s *ExponentialStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		return capped(wait, s.Cap)
+	}
+	return exponential(s.Base, retry, s.Cap)
+}
+
+// ExponentialJitterStrategy is an exponential backoff strategy which adds full jitter, i.e.
+// sleep = rand(0, min(cap, base*2^retry)), to avoid a thundering herd of retries when many
+// callers fail at the same time
+type ExponentialJitterStrategy struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// NewExponentialJitterStrategy creates a new exponential backoff with full jitter strategy
+func NewExponentialJitterStrategy(base, cap time.Duration) *ExponentialJitterStrategy {
+	return &ExponentialJitterStrategy{Base: base, Cap: cap}
+}
+
+// Backoff waits a random duration between 0 and base*2^retry (capped at Cap), honoring
+// Retry-After if the response has one
+func (s *ExponentialJitterStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		return capped(wait, s.Cap)
+	}
+
+	max := exponential(s.Base, retry, s.Cap)
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
+
+// exponential returns base*2^retry, stopping as soon as doubling further would exceed cap or
+// overflow time.Duration's underlying int64, rather than shifting retry unboundedly
+func exponential(base time.Duration, retry int, cap time.Duration) time.Duration {
+	d := base
+	for i := 0; i < retry; i++ {
+		next := d * 2
+		if next < d { // overflowed
+			if cap > 0 {
+				return cap
+			}
+			return d
+		}
+		d = next
+		if cap > 0 && d > cap {
+			return cap
+		}
+	}
+	return capped(d, cap)
+}
+
+func capped(d, cap time.Duration) time.Duration {
+	if d < 0 {
+		if cap > 0 {
+			return cap
+		}
+		return 0
+	}
+	if cap > 0 && d > cap {
+		return cap
+	}
+	return d
+}
+
+// parses a Retry-After header, in either delta-seconds or HTTP-date form
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// RetryConfig is our configuration for what requests to retry and with what backoff. It is
+// itself an implementation of RetryStrategy-based retrying, kept for backwards compatibility
+// with callers constructing it directly.
+type RetryConfig struct {
+	Strategy    RetryStrategy
+	ShouldRetry func(request *http.Request, response *http.Response, backoff time.Duration) bool
+}
+
+// NewFixedRetries creates a new RetryConfig which retries the given number of times, waiting the
+// given number of seconds before each attempt, or instead as long as the response's Retry-After
+// header says if it has one
+func NewFixedRetries(backoffsInSeconds ...int) *RetryConfig {
+	backoffs := make([]time.Duration, len(backoffsInSeconds))
+	for i, s := range backoffsInSeconds {
+		backoffs[i] = time.Duration(s) * time.Second
+	}
+
+	return &RetryConfig{
+		Strategy:    &fixedStrategy{backoffs: backoffs},
+		ShouldRetry: DefaultShouldRetry,
+	}
+}
+
+// NewExponentialRetries creates a new RetryConfig which retries up to maxRetries times using
+// exponential backoff with full jitter
+func NewExponentialRetries(maxRetries int, base, cap time.Duration) *RetryConfig {
+	return &RetryConfig{
+		Strategy:    &maxRetriesStrategy{max: maxRetries, strategy: NewExponentialJitterStrategy(base, cap)},
+		ShouldRetry: DefaultShouldRetry,
+	}
+}
+
+// MaxRetries returns the maximum number of retries this config allows
+func (r *RetryConfig) MaxRetries() int {
+	if m, ok := r.Strategy.(interface{ MaxRetries() int }); ok {
+		return m.MaxRetries()
+	}
+	return 0
+}
+
+// Backoff returns how long to wait before the given retry attempt, given the response from the
+// previous attempt if there was one
+func (r *RetryConfig) Backoff(retry int, response *http.Response) time.Duration {
+	return r.Strategy.Backoff(retry, response)
+}
+
+// DefaultShouldRetry is the default retry predicate, which retries on connection errors and on
+// 429 and 5xx responses
+func DefaultShouldRetry(request *http.Request, response *http.Response, backoff time.Duration) bool {
+	if response == nil {
+		return true
+	}
+	return response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+}
+
+// fixedStrategy retries a fixed number of times using a fixed table of backoffs
+type fixedStrategy struct {
+	backoffs []time.Duration
+}
+
+func (s *fixedStrategy) MaxRetries() int { return len(s.backoffs) }
+
+func (s *fixedStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		return wait
+	}
+	return s.backoffs[retry]
+}
+
+// maxRetriesStrategy wraps a RetryStrategy with a fixed maximum number of retries
+type maxRetriesStrategy struct {
+	max      int
+	strategy RetryStrategy
+}
+
+func (s *maxRetriesStrategy) MaxRetries() int { return s.max }
+
+func (s *maxRetriesStrategy) Backoff(retry int, response *http.Response) time.Duration {
+	return s.strategy.Backoff(retry, response)
+}
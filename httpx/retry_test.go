@@ -0,0 +1,70 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nyaruka/gocommon/httpx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceRequestor struct {
+	responses []*http.Response
+	calls     int
+}
+
+func (r *sequenceRequestor) Do(client *http.Client, request *http.Request) (*http.Response, error) {
+	response := r.responses[r.calls]
+	r.calls++
+	return response, nil
+}
+
+func TestRetryAfter(t *testing.T) {
+	defer httpx.SetRequestor(httpx.DefaultRequestor)
+
+	rateLimited := httptest.NewRecorder()
+	rateLimited.Header().Set("Retry-After", "2")
+	rateLimited.Code = http.StatusTooManyRequests
+
+	ok := httptest.NewRecorder()
+	ok.Code = http.StatusOK
+
+	httpx.SetRequestor(&sequenceRequestor{responses: []*http.Response{rateLimited.Result(), ok.Result()}})
+
+	retries := httpx.NewExponentialRetries(1, time.Millisecond, time.Minute)
+
+	request, err := http.NewRequest("GET", "http://example.com", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	response, err := httpx.Do(http.DefaultClient, request, retries, nil)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.GreaterOrEqual(t, elapsed, 2*time.Second)
+	assert.Less(t, elapsed, 3*time.Second)
+}
+
+func TestExponentialStrategyDoesntOverflowOrIgnoreCap(t *testing.T) {
+	strategy := httpx.NewExponentialStrategy(time.Second, time.Hour)
+
+	backoff := strategy.Backoff(40, nil)
+
+	assert.GreaterOrEqual(t, backoff, time.Duration(0))
+	assert.LessOrEqual(t, backoff, time.Hour)
+	assert.Equal(t, time.Hour, backoff)
+}
+
+func TestExponentialJitterStrategyDoesntOverflowOrIgnoreCap(t *testing.T) {
+	strategy := httpx.NewExponentialJitterStrategy(time.Second, time.Hour)
+
+	backoff := strategy.Backoff(40, nil)
+
+	assert.GreaterOrEqual(t, backoff, time.Duration(0))
+	assert.LessOrEqual(t, backoff, time.Hour)
+}
@@ -0,0 +1,296 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RequestTargetHeader is the pseudo-header used by the HTTP Signatures scheme to cover the
+// request method and path
+const RequestTargetHeader = "(request-target)"
+
+// Signer signs outgoing requests using the HTTP Signatures scheme (draft-cavage-http-signatures)
+// popularized by ActivityPub servers such as Mastodon and Pleroma. It adds a Digest header
+// covering the request body and a Signature header covering the given list of headers.
+type Signer struct {
+	KeyID     string
+	Key       crypto.Signer
+	Algorithm string
+	Headers   []string
+}
+
+// NewSigner creates a new Signer for the given key ID and private key, which must be an
+// *rsa.PrivateKey or ed25519.PrivateKey. headers is the ordered list of headers to include in
+// the signature, e.g. []string{RequestTargetHeader, "host", "date", "digest"}.
+func NewSigner(keyID string, key crypto.Signer, headers []string) (*Signer, error) {
+	var algorithm string
+
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		algorithm = "rsa-sha256"
+	case ed25519.PrivateKey:
+		algorithm = "ed25519"
+	default:
+		return nil, errors.Errorf("unsupported key type %T", key)
+	}
+
+	return &Signer{KeyID: keyID, Key: key, Algorithm: algorithm, Headers: headers}, nil
+}
+
+// Sign adds Digest and Signature headers to the given request
+func (s *Signer) Sign(request *http.Request) error {
+	if err := addDigest(request); err != nil {
+		return err
+	}
+
+	signingString, err := buildSigningString(request, s.Headers)
+	if err != nil {
+		return err
+	}
+
+	signature, err := s.sign([]byte(signingString))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Signature", fmt.Sprintf(
+		`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.KeyID, s.Algorithm, strings.Join(s.Headers, " "), base64.StdEncoding.EncodeToString(signature),
+	))
+
+	return nil
+}
+
+func (s *Signer) sign(signingString []byte) ([]byte, error) {
+	switch key := s.Key.(type) {
+	case *rsa.PrivateKey:
+		hashed := sha256.Sum256(signingString)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, signingString), nil
+	default:
+		return nil, errors.Errorf("unsupported key type %T", s.Key)
+	}
+}
+
+// PublicKeyResolver looks up the public key to use for verification by key ID, e.g. an actor's
+// key URL in the ActivityPub case
+type PublicKeyResolver func(keyID string) (crypto.PublicKey, error)
+
+// Verifier verifies the Signature header on inbound requests
+type Verifier struct {
+	Resolve PublicKeyResolver
+
+	// Required is the set of headers the signature must cover, or nil to use the default of
+	// (request-target) plus digest for requests with a body. A malicious or careless signer
+	// can't be trusted to declare an adequate headers list itself, so this is enforced against
+	// the signature's own claimed coverage rather than read from it.
+	Required []string
+}
+
+// NewVerifier creates a new Verifier which resolves public keys with the given callback
+func NewVerifier(resolve PublicKeyResolver) *Verifier {
+	return &Verifier{Resolve: resolve}
+}
+
+// Verify checks the Signature header on the given request, rebuilding the signing string from
+// the headers it claims to cover and verifying it against the public key returned by Resolve
+func (v *Verifier) Verify(request *http.Request) error {
+	sig, err := parseSignatureHeader(request.Header.Get("Signature"))
+	if err != nil {
+		return err
+	}
+
+	for _, required := range v.requiredHeaders(request) {
+		if !containsHeaderFold(sig.headers, required) {
+			return errors.Errorf("signature doesn't cover required header %s", required)
+		}
+	}
+
+	if err := verifyDigest(request); err != nil {
+		return err
+	}
+
+	signingString, err := buildSigningString(request, sig.headers)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := v.Resolve(sig.keyID)
+	if err != nil {
+		return errors.Wrapf(err, "error resolving public key for %s", sig.keyID)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(sig.signature)
+	if err != nil {
+		return errors.Wrap(err, "unable to decode signature")
+	}
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		hashed := sha256.Sum256([]byte(signingString))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature); err != nil {
+			return errors.Wrap(err, "signature verification failed")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, []byte(signingString), signature) {
+			return errors.New("signature verification failed")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	return nil
+}
+
+// returns the headers this verifier requires the signature to cover for the given request
+func (v *Verifier) requiredHeaders(request *http.Request) []string {
+	if v.Required != nil {
+		return v.Required
+	}
+
+	required := []string{RequestTargetHeader}
+	if request.ContentLength != 0 { // -1 means a body of unknown length
+		required = append(required, "digest")
+	}
+	return required
+}
+
+func containsHeaderFold(headers []string, name string) bool {
+	for _, h := range headers {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+type signatureHeader struct {
+	keyID     string
+	algorithm string
+	headers   []string
+	signature string
+}
+
+// parses a `Signature: keyId="…",algorithm="…",headers="…",signature="…"` header value
+func parseSignatureHeader(value string) (*signatureHeader, error) {
+	if value == "" {
+		return nil, errors.New("missing Signature header")
+	}
+
+	fields := map[string]string{}
+	for _, part := range strings.Split(value, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		fields[strings.TrimSpace(kv[0])] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+	}
+
+	sig := &signatureHeader{
+		keyID:     fields["keyId"],
+		algorithm: fields["algorithm"],
+		signature: fields["signature"],
+	}
+	if fields["headers"] != "" {
+		sig.headers = strings.Split(fields["headers"], " ")
+	} else {
+		sig.headers = []string{"date"}
+	}
+
+	if sig.keyID == "" || sig.signature == "" {
+		return nil, errors.New("Signature header missing keyId or signature")
+	}
+
+	return sig, nil
+}
+
+// builds the signing string for the given headers, resolving the (request-target) pseudo-header
+// and the host header specially since neither is available via request.Header
+func buildSigningString(request *http.Request, headers []string) (string, error) {
+	lines := make([]string, len(headers))
+
+	for i, header := range headers {
+		header = strings.ToLower(header)
+
+		var value string
+		switch header {
+		case RequestTargetHeader:
+			value = fmt.Sprintf("%s %s", strings.ToLower(request.Method), request.URL.RequestURI())
+		case "host":
+			value = request.Host
+			if value == "" {
+				value = request.URL.Host
+			}
+		default:
+			value = request.Header.Get(header)
+		}
+
+		if value == "" {
+			return "", errors.Errorf("missing value for header %s", header)
+		}
+
+		lines[i] = fmt.Sprintf("%s: %s", header, value)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// reads the request body, restoring it afterwards, and sets a Digest header covering it
+func addDigest(request *http.Request) error {
+	body, err := readAndRestoreBody(request)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(body)
+	request.Header.Set("Digest", fmt.Sprintf("SHA-256=%s", base64.StdEncoding.EncodeToString(digest[:])))
+	return nil
+}
+
+// checks that the Digest header, if present, matches the actual request body
+func verifyDigest(request *http.Request) error {
+	existing := request.Header.Get("Digest")
+	if existing == "" {
+		return nil
+	}
+
+	body, err := readAndRestoreBody(request)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(body)
+	expected := fmt.Sprintf("SHA-256=%s", base64.StdEncoding.EncodeToString(digest[:]))
+
+	if existing != expected {
+		return errors.New("Digest header doesn't match request body")
+	}
+	return nil
+}
+
+func readAndRestoreBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading request body")
+	}
+	request.Body.Close()
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
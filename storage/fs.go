@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nyaruka/gocommon/httpx"
+)
+
+// fsStorage is a Storage backend which reads and writes files to the local file system, used for
+// testing and for standalone deployments which don't need something like S3
+type fsStorage struct {
+	directory string
+}
+
+// NewFS creates a new file system storage backend rooted at the given directory
+func NewFS(directory string) Storage {
+	return &fsStorage{directory: directory}
+}
+
+// Name returns the name of this storage backend
+func (s *fsStorage) Name() string { return "file system" }
+
+// Test checks that we can write to our root directory
+func (s *fsStorage) Test() error {
+	if err := os.MkdirAll(s.directory, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.resolve("test.txt"), []byte("test"), 0644)
+}
+
+func (s *fsStorage) resolve(path string) string {
+	return filepath.Join(s.directory, path)
+}
+
+// Get fetches the full contents of the file at the given path
+func (s *fsStorage) Get(path string) (string, []byte, error) {
+	data, err := os.ReadFile(s.resolve(path))
+	if err != nil {
+		return "", nil, err
+	}
+	return httpx.DetectContentType(data), data, nil
+}
+
+// GetRange fetches length bytes of the file at the given path starting at offset, seeking
+// directly to it rather than reading the whole file into memory
+func (s *fsStorage) GetRange(path string, offset, length int64) (io.ReadCloser, error) {
+	f, err := os.Open(s.resolve(path))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &rangeReader{LimitedReader: io.LimitedReader{R: f, N: length}, file: f}, nil
+}
+
+// Put writes the given contents to the file at the given path, creating any parent directories
+// needed
+func (s *fsStorage) Put(path string, contentType string, contents []byte) (string, error) {
+	full := s.resolve(path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(full, contents, 0644); err != nil {
+		return "", err
+	}
+	return full, nil
+}
+
+// PutStream copies size bytes from r to the file at the given path, without buffering the whole
+// payload in memory, writing to a temp file in the same directory first so the final rename is
+// atomic
+func (s *fsStorage) PutStream(path string, contentType string, r io.Reader, size int64) (string, error) {
+	full := s.resolve(path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.CopyN(tmp, r, size); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), full); err != nil {
+		return "", err
+	}
+
+	return full, nil
+}
+
+// Stat returns the size and content type of the file at the given path, only reading enough of
+// it to sniff the content type
+func (s *fsStorage) Stat(path string) (int64, string, error) {
+	f, err := os.Open(s.resolve(path))
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, "", err
+	}
+
+	head := make([]byte, 512)
+	n, err := f.Read(head)
+	if err != nil && err != io.EOF {
+		return 0, "", err
+	}
+
+	return info.Size(), httpx.DetectContentType(head[:n]), nil
+}
+
+// rangeReader limits reads to a section of a file and closes the underlying file when done
+type rangeReader struct {
+	io.LimitedReader
+	file *os.File
+}
+
+func (r *rangeReader) Close() error { return r.file.Close() }
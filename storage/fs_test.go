@@ -1,7 +1,9 @@
 package storage_test
 
 import (
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/nyaruka/gocommon/storage"
@@ -29,5 +31,25 @@ func TestFS(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, []byte(`hello world`), data)
 
+	size, contentType, err := s.Stat("/foo/bar.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), size)
+	assert.Equal(t, "text/plain; charset=utf-8", contentType)
+
+	r, err := s.GetRange("/foo/bar.txt", 6, 5)
+	assert.NoError(t, err)
+	ranged, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Close())
+	assert.Equal(t, []byte(`world`), ranged)
+
+	url, err = s.PutStream("/foo/baz.txt", "text/plain", strings.NewReader("stream me"), 9)
+	assert.NoError(t, err)
+	assert.Equal(t, "_testing/foo/baz.txt", url)
+
+	_, data, err = s.Get("/foo/baz.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`stream me`), data)
+
 	require.NoError(t, os.RemoveAll("_testing"))
 }
@@ -0,0 +1,30 @@
+package storage
+
+import "io"
+
+// Storage is the interface for our storage backends, used to store and retrieve media such as
+// attachments, exports and recordings
+type Storage interface {
+	// Name returns the name of the storage backend, e.g. "s3" or "file system"
+	Name() string
+
+	// Test checks that we can write to the backend, returning an error if not
+	Test() error
+
+	// Get fetches the full contents of the file at the given path, returning its content type
+	Get(path string) (string, []byte, error)
+
+	// GetRange fetches length bytes of the file at the given path starting at offset, allowing
+	// large media to be served with HTTP Range responses without buffering the whole file
+	GetRange(path string, offset, length int64) (io.ReadCloser, error)
+
+	// Put writes the given contents to the file at the given path, returning its URL
+	Put(path string, contentType string, contents []byte) (string, error)
+
+	// PutStream writes size bytes read from r to the file at the given path, returning its URL,
+	// without buffering the whole payload in memory
+	PutStream(path string, contentType string, r io.Reader, size int64) (string, error)
+
+	// Stat returns the size and content type of the file at the given path
+	Stat(path string) (int64, string, error)
+}
@@ -2,6 +2,7 @@ package urns
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -39,22 +40,238 @@ const (
 
 	// ExternalScheme is the scheme used for externally defined identifiers
 	ExternalScheme string = "ext"
+
+	// WhatsAppScheme is the scheme used for WhatsApp identifiers
+	WhatsAppScheme string = "whatsapp"
+
+	// InstagramScheme is the scheme used for Instagram identifiers
+	InstagramScheme string = "instagram"
+
+	// SignalScheme is the scheme used for Signal identifiers
+	SignalScheme string = "signal"
+
+	// DiscordScheme is the scheme used for Discord identifiers
+	DiscordScheme string = "discord"
+
+	// MatrixScheme is the scheme used for Matrix identifiers
+	MatrixScheme string = "matrix"
+
+	// ActivityPubScheme is the scheme used for ActivityPub actor URIs
+	ActivityPubScheme string = "ap"
 )
 
-var validSchemes = map[string]bool{
-	TelScheme:       true,
-	FacebookScheme:  true,
-	TelegramScheme:  true,
-	TwitterScheme:   true,
-	TwitterIDScheme: true,
-	ViberScheme:     true,
-	LineScheme:      true,
-	JiochatScheme:   true,
-	EmailScheme:     true,
-	ExternalScheme:  true,
+var telRegex = regexp.MustCompile(`[^0-9a-z]`)
+
+// normalizePhoneLike strips punctuation from a phone-number-like path, preserving a leading '+'
+func normalizePhoneLike(path string) string {
+	trimmed := strings.ToLower(strings.TrimSpace(path))
+	hasPlus := strings.HasPrefix(trimmed, "+")
+	stripped := telRegex.ReplaceAllString(trimmed, "")
+	if hasPlus {
+		return "+" + stripped
+	}
+	return stripped
 }
 
-var telRegex = regexp.MustCompile(`[^0-9a-z]`)
+// SchemeHandler defines how a URN scheme normalizes, validates and formats its paths. Downstream
+// projects can implement this to add support for schemes this package doesn't know about, by
+// registering a handler with RegisterScheme.
+type SchemeHandler interface {
+	// Normalize returns a normalized version of path for this scheme
+	Normalize(path string) (string, error)
+
+	// Validate returns an error if path or display aren't valid for this scheme
+	Validate(path, display string) error
+
+	// Format returns a URN for this scheme built from the given path and display
+	Format(path, display string) URN
+}
+
+var schemes = map[string]SchemeHandler{}
+
+// RegisterScheme registers the handler to use for the given scheme, replacing any existing
+// handler for it. This lets downstream projects add support for new schemes without needing to
+// patch this package.
+func RegisterScheme(scheme string, handler SchemeHandler) {
+	schemes[scheme] = handler
+}
+
+func init() {
+	RegisterScheme(TelScheme, &telHandler{})
+	RegisterScheme(FacebookScheme, &genericHandler{scheme: FacebookScheme})
+	RegisterScheme(TelegramScheme, &genericHandler{scheme: TelegramScheme})
+	RegisterScheme(TwitterScheme, &genericHandler{scheme: TwitterScheme})
+	RegisterScheme(TwitterIDScheme, &genericHandler{scheme: TwitterIDScheme})
+	RegisterScheme(ViberScheme, &genericHandler{scheme: ViberScheme})
+	RegisterScheme(LineScheme, &genericHandler{scheme: LineScheme})
+	RegisterScheme(JiochatScheme, &genericHandler{scheme: JiochatScheme})
+	RegisterScheme(EmailScheme, &genericHandler{scheme: EmailScheme})
+	RegisterScheme(ExternalScheme, &genericHandler{scheme: ExternalScheme})
+	RegisterScheme(WhatsAppScheme, &whatsappHandler{})
+	RegisterScheme(InstagramScheme, &instagramHandler{})
+	RegisterScheme(SignalScheme, &signalHandler{})
+	RegisterScheme(DiscordScheme, &discordHandler{})
+	RegisterScheme(MatrixScheme, &matrixHandler{})
+	RegisterScheme(ActivityPubScheme, &activityPubHandler{})
+}
+
+// genericHandler is used for schemes which have no special normalization or validation rules
+// beyond requiring a non-empty path
+type genericHandler struct {
+	scheme string
+}
+
+func (h *genericHandler) Normalize(path string) (string, error) { return path, nil }
+
+func (h *genericHandler) Validate(path, display string) error {
+	if path == "" {
+		return fmt.Errorf("invalid path for scheme '%s'", h.scheme)
+	}
+	return nil
+}
+
+func (h *genericHandler) Format(path, display string) URN {
+	return newURN(h.scheme, path, display)
+}
+
+// telHandler normalizes tel paths down to lowercase letters and digits, stripping punctuation like
+// spaces and dashes but preserving a leading '+' since that's significant for E.164 numbers
+type telHandler struct{}
+
+func (h *telHandler) Normalize(path string) (string, error) {
+	return normalizePhoneLike(path), nil
+}
+
+func (h *telHandler) Validate(path, display string) error {
+	if path == "" {
+		return fmt.Errorf("invalid tel number '%s'", path)
+	}
+	return nil
+}
+
+func (h *telHandler) Format(path, display string) URN {
+	return newURN(TelScheme, path, display)
+}
+
+// whatsappHandler normalizes WhatsApp identifiers down to the E.164 digits they're based on,
+// without the leading '+' that WhatsApp's own API omits
+type whatsappHandler struct{}
+
+var nonDigitRegex = regexp.MustCompile(`[^0-9]`)
+
+func (h *whatsappHandler) Normalize(path string) (string, error) {
+	return nonDigitRegex.ReplaceAllString(path, ""), nil
+}
+
+func (h *whatsappHandler) Validate(path, display string) error {
+	if path == "" {
+		return fmt.Errorf("invalid whatsapp id '%s'", path)
+	}
+	return nil
+}
+
+func (h *whatsappHandler) Format(path, display string) URN {
+	return newURN(WhatsAppScheme, path, display)
+}
+
+// signalHandler normalizes Signal identifiers the same way as tel numbers since Signal accounts
+// are phone numbers
+type signalHandler struct{}
+
+func (h *signalHandler) Normalize(path string) (string, error) {
+	return normalizePhoneLike(path), nil
+}
+
+func (h *signalHandler) Validate(path, display string) error {
+	if path == "" {
+		return fmt.Errorf("invalid signal id '%s'", path)
+	}
+	return nil
+}
+
+func (h *signalHandler) Format(path, display string) URN {
+	return newURN(SignalScheme, path, display)
+}
+
+// discordHandler validates Discord identifiers, which are numeric snowflake ids
+type discordHandler struct{}
+
+func (h *discordHandler) Normalize(path string) (string, error) {
+	return strings.TrimSpace(path), nil
+}
+
+func (h *discordHandler) Validate(path, display string) error {
+	if path == "" || nonDigitRegex.MatchString(path) {
+		return fmt.Errorf("invalid discord id '%s'", path)
+	}
+	return nil
+}
+
+func (h *discordHandler) Format(path, display string) URN {
+	return newURN(DiscordScheme, path, display)
+}
+
+// instagramRegex matches valid Instagram usernames and numeric ids: letters, digits, periods and
+// underscores
+var instagramRegex = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// instagramHandler validates Instagram identifiers
+type instagramHandler struct{}
+
+func (h *instagramHandler) Normalize(path string) (string, error) {
+	return strings.ToLower(strings.TrimSpace(path)), nil
+}
+
+func (h *instagramHandler) Validate(path, display string) error {
+	if !instagramRegex.MatchString(path) {
+		return fmt.Errorf("invalid instagram id '%s'", path)
+	}
+	return nil
+}
+
+func (h *instagramHandler) Format(path, display string) URN {
+	return newURN(InstagramScheme, path, display)
+}
+
+// matrixRegex matches Matrix user ids of the form @localpart:domain
+var matrixRegex = regexp.MustCompile(`^@[a-z0-9._=/+-]+:[a-zA-Z0-9.-]+$`)
+
+// matrixHandler validates Matrix user identifiers
+type matrixHandler struct{}
+
+func (h *matrixHandler) Normalize(path string) (string, error) {
+	return strings.TrimSpace(path), nil
+}
+
+func (h *matrixHandler) Validate(path, display string) error {
+	if !matrixRegex.MatchString(path) {
+		return fmt.Errorf("invalid matrix id '%s'", path)
+	}
+	return nil
+}
+
+func (h *matrixHandler) Format(path, display string) URN {
+	return newURN(MatrixScheme, path, display)
+}
+
+// activityPubHandler validates ActivityPub actor URIs, which must be absolute http(s) URLs
+type activityPubHandler struct{}
+
+func (h *activityPubHandler) Normalize(path string) (string, error) {
+	return strings.TrimSpace(path), nil
+}
+
+func (h *activityPubHandler) Validate(path, display string) error {
+	parsed, err := url.Parse(path)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid activitypub actor uri '%s'", path)
+	}
+	return nil
+}
+
+func (h *activityPubHandler) Format(path, display string) URN {
+	return newURN(ActivityPubScheme, path, display)
+}
 
 // URN represents a Universal Resource Name, we use this for contact identifiers like phone numbers etc..
 type URN string
@@ -112,42 +329,54 @@ var NilURN = URN("")
 
 // NewTelURNForCountry returns a URN for the passed in telephone number and country code ("US")
 func NewTelURNForCountry(number string, country string) URN {
+	handler := schemes[TelScheme]
+	normalized, _ := handler.Normalize(number)
+
 	// add on a plus if it looks like it could be a fully qualified number
-	number = telRegex.ReplaceAllString(strings.ToLower(strings.TrimSpace(number)), "")
-	parseNumber := number
-	if len(number) >= 11 && !(strings.HasPrefix(number, "+") || strings.HasPrefix(number, "0")) {
-		parseNumber = fmt.Sprintf("+%s", number)
+	parseNumber := normalized
+	if len(normalized) >= 11 && !(strings.HasPrefix(normalized, "+") || strings.HasPrefix(normalized, "0")) {
+		parseNumber = fmt.Sprintf("+%s", normalized)
 	}
 
-	normalized, err := phonenumbers.Parse(parseNumber, country)
+	parsed, err := phonenumbers.Parse(parseNumber, country)
 
 	// couldn't parse it, use the original number
 	if err != nil {
-		return newURN(TelScheme, number, "")
+		return handler.Format(normalized, "")
 	}
 
 	// if it looks valid, return it
-	if phonenumbers.IsValidNumber(normalized) {
-		return newURN(TelScheme, phonenumbers.Format(normalized, phonenumbers.E164), "")
+	if phonenumbers.IsValidNumber(parsed) {
+		return handler.Format(phonenumbers.Format(parsed, phonenumbers.E164), "")
 	}
 
 	// this doesn't look like anything we recognize, use the original number
-	return newURN(TelScheme, number, "")
+	return handler.Format(normalized, "")
 }
 
 // NewTelegramURN returns a URN for the passed in telegram identifier
 func NewTelegramURN(identifier int64, display string) URN {
-	return newURN(TelegramScheme, strconv.FormatInt(identifier, 10), display)
+	return schemes[TelegramScheme].Format(strconv.FormatInt(identifier, 10), display)
 }
 
-// NewURNFromParts returns a new URN for the given scheme, path and display
+// NewURNFromParts returns a new URN for the given scheme, path and display, dispatching
+// normalization and validation to the handler registered for scheme
 func NewURNFromParts(scheme string, path string, display string) (URN, error) {
 	scheme = strings.ToLower(scheme)
-	if !validSchemes[scheme] {
+	handler := schemes[scheme]
+	if handler == nil {
 		return NilURN, fmt.Errorf("invalid scheme '%s'", scheme)
 	}
 
-	return newURN(scheme, path, display), nil
+	normalized, err := handler.Normalize(path)
+	if err != nil {
+		return NilURN, err
+	}
+	if err := handler.Validate(normalized, display); err != nil {
+		return NilURN, err
+	}
+
+	return handler.Format(normalized, display), nil
 }
 
 // private utility method to create a URN from a scheme and path
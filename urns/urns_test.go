@@ -0,0 +1,87 @@
+package urns_test
+
+import (
+	"testing"
+
+	"github.com/nyaruka/gocommon/urns"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewURNFromParts(t *testing.T) {
+	tests := []struct {
+		scheme  string
+		path    string
+		display string
+		urn     urns.URN
+		error   string
+	}{
+		{scheme: urns.TelScheme, path: "+1 415-555-1234", urn: urns.URN("tel:+14155551234")},
+		{scheme: urns.TelScheme, path: "  ", error: "invalid tel number ''"},
+
+		{scheme: urns.FacebookScheme, path: "1234567890", urn: urns.URN("facebook:1234567890")},
+		{scheme: urns.FacebookScheme, path: "", error: "invalid path for scheme 'facebook'"},
+
+		{scheme: urns.WhatsAppScheme, path: "+1 (415) 555-1234", urn: urns.URN("whatsapp:14155551234")},
+		{scheme: urns.WhatsAppScheme, path: "", error: "invalid whatsapp id ''"},
+
+		{scheme: urns.SignalScheme, path: "+1 415-555-1234", urn: urns.URN("signal:+14155551234")},
+		{scheme: urns.SignalScheme, path: "", error: "invalid signal id ''"},
+
+		{scheme: urns.DiscordScheme, path: "694925022472224868", urn: urns.URN("discord:694925022472224868")},
+		{scheme: urns.DiscordScheme, path: "not-a-snowflake", error: "invalid discord id 'not-a-snowflake'"},
+
+		{scheme: urns.InstagramScheme, path: "Some.User_99", urn: urns.URN("instagram:some.user_99")},
+		{scheme: urns.InstagramScheme, path: "not a valid handle!", error: "invalid instagram id 'not a valid handle!'"},
+
+		{scheme: urns.MatrixScheme, path: "@bob:example.org", urn: urns.URN("matrix:@bob:example.org")},
+		{scheme: urns.MatrixScheme, path: "bob", error: "invalid matrix id 'bob'"},
+
+		{scheme: urns.ActivityPubScheme, path: "https://example.org/users/bob", urn: urns.URN("ap:https://example.org/users/bob")},
+		{scheme: urns.ActivityPubScheme, path: "not a url", error: "invalid activitypub actor uri 'not a url'"},
+
+		{scheme: "xxx", path: "1234", error: "invalid scheme 'xxx'"},
+	}
+
+	for _, tc := range tests {
+		urn, err := urns.NewURNFromParts(tc.scheme, tc.path, tc.display)
+
+		if tc.error != "" {
+			assert.EqualError(t, err, tc.error, "error mismatch for scheme %s path %s", tc.scheme, tc.path)
+		} else {
+			assert.NoError(t, err, "unexpected error for scheme %s path %s", tc.scheme, tc.path)
+			assert.Equal(t, tc.urn, urn, "URN mismatch for scheme %s path %s", tc.scheme, tc.path)
+		}
+	}
+}
+
+func TestNewTelURNForCountry(t *testing.T) {
+	assert.Equal(t, urns.URN("tel:+14155551234"), urns.NewTelURNForCountry("(415) 555-1234", "US"))
+	assert.Equal(t, urns.URN("tel:+14155551234"), urns.NewTelURNForCountry("+1 415 555 1234", "US"))
+
+	// numbers that don't parse as valid for the country are kept as-is, normalized
+	assert.Equal(t, urns.URN("tel:123"), urns.NewTelURNForCountry("123", "US"))
+}
+
+func TestNewTelegramURN(t *testing.T) {
+	assert.Equal(t, urns.URN("telegram:12345"), urns.NewTelegramURN(12345, ""))
+	assert.Equal(t, urns.URN("telegram:12345#bob"), urns.NewTelegramURN(12345, "Bob"))
+}
+
+func TestRegisterScheme(t *testing.T) {
+	urns.RegisterScheme("mastodon", customHandler{})
+
+	urn, err := urns.NewURNFromParts("mastodon", "bob@example.org", "")
+	assert.NoError(t, err)
+	assert.Equal(t, urns.URN("mastodon:bob@example.org"), urn)
+}
+
+type customHandler struct{}
+
+func (h customHandler) Normalize(path string) (string, error) { return path, nil }
+
+func (h customHandler) Validate(path, display string) error { return nil }
+
+func (h customHandler) Format(path, display string) urns.URN {
+	return urns.URN("mastodon:" + path)
+}